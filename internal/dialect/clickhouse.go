@@ -0,0 +1,31 @@
+package dialect
+
+// ClickHouse is the DialectQuery implementation for ClickHouse. ClickHouse
+// has no transactional DELETE statement, so DeleteVersion uses a mutation
+// (`alter table ... delete`) instead.
+type ClickHouse struct{}
+
+// CreateVersionTable returns the statement that creates the dbVersion table.
+func (ClickHouse) CreateVersionTable() string {
+	return `create table if not exists dbVersion (version Int32, createdAt Int64) engine = MergeTree() order by version`
+}
+
+// InsertVersion returns the statement that records a migration version as applied.
+func (ClickHouse) InsertVersion() string {
+	return `insert into dbVersion (version, createdAt) values (?, ?)`
+}
+
+// DeleteVersion returns the statement that removes a migration version's row.
+func (ClickHouse) DeleteVersion() string {
+	return `alter table dbVersion delete where version = ?`
+}
+
+// GetLatestVersion returns the statement that selects the highest applied version.
+func (ClickHouse) GetLatestVersion() string {
+	return `select * from dbVersion order by version desc limit 1`
+}
+
+// ListAppliedVersions returns the statement that lists all applied versions.
+func (ClickHouse) ListAppliedVersions() string {
+	return `select version from dbVersion order by version asc`
+}
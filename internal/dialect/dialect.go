@@ -0,0 +1,54 @@
+// Package dialect provides the SQL needed to track applied migrations across
+// the different databases the migrate package supports. Each dialect knows
+// its own placeholder syntax for the dbVersion table. A row's presence in
+// dbVersion means its version is applied; DeleteVersion removes the row
+// entirely rather than marking it unapplied.
+package dialect
+
+import "hash/fnv"
+
+// HashLockName hashes name into an int64, suitable for dialects (like
+// PostgreSQL) whose advisory locks are keyed by a 64-bit integer.
+func HashLockName(name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+
+	return int64(h.Sum64())
+}
+
+// Locker is implemented by dialects that support advisory locking, so that
+// only one process at a time applies migrations. Dialects without native
+// advisory locking fall back to a sentinel row in the dbVersion table.
+type Locker interface {
+	// LockStatement returns the statement that acquires the advisory lock.
+	LockStatement() string
+
+	// UnlockStatement returns the statement that releases the advisory lock.
+	UnlockStatement() string
+
+	// LockKey returns the parameter passed to the lock/unlock statements for
+	// the given lock name. Callers should pass a name unique to the Service
+	// (or the database it migrates), since dialects that key on a single
+	// shared lock name would otherwise serialize unrelated services against
+	// each other.
+	LockKey(name string) interface{}
+}
+
+// DialectQuery provides the SQL statements used to create and query the
+// dbVersion table for a specific database dialect.
+type DialectQuery interface {
+	// CreateVersionTable returns the statement that creates the dbVersion table.
+	CreateVersionTable() string
+
+	// InsertVersion returns the statement that records a migration version as applied.
+	InsertVersion() string
+
+	// DeleteVersion returns the statement that removes a migration version's row.
+	DeleteVersion() string
+
+	// GetLatestVersion returns the statement that selects the highest applied version.
+	GetLatestVersion() string
+
+	// ListAppliedVersions returns the statement that lists all applied versions.
+	ListAppliedVersions() string
+}
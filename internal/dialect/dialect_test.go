@@ -0,0 +1,69 @@
+package dialect_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rickbassham/database/internal/dialect"
+)
+
+func TestHashLockName(t *testing.T) {
+	a := dialect.HashLockName("one")
+	b := dialect.HashLockName("one")
+	c := dialect.HashLockName("two")
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
+
+func TestDialectQueryStatements(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect dialect.DialectQuery
+	}{
+		{"Postgres", dialect.Postgres{}},
+		{"MySQL", dialect.MySQL{}},
+		{"SQLite3", dialect.SQLite3{}},
+		{"SQLServer", dialect.SQLServer{}},
+		{"ClickHouse", dialect.ClickHouse{}},
+		{"Redshift", dialect.Redshift{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, stmt := range []string{
+				tt.dialect.CreateVersionTable(),
+				tt.dialect.InsertVersion(),
+				tt.dialect.DeleteVersion(),
+				tt.dialect.GetLatestVersion(),
+				tt.dialect.ListAppliedVersions(),
+			} {
+				assert.NotEmpty(t, stmt)
+				assert.NotContains(t, stmt, "isApplied")
+			}
+		})
+	}
+}
+
+func TestPostgresLocker(t *testing.T) {
+	var l dialect.Locker = dialect.Postgres{}
+
+	assert.Equal(t, "select pg_advisory_lock($1)", l.LockStatement())
+	assert.Equal(t, "select pg_advisory_unlock($1)", l.UnlockStatement())
+	assert.Equal(t, dialect.HashLockName("my-lock"), l.LockKey("my-lock"))
+}
+
+func TestMySQLLocker(t *testing.T) {
+	var l dialect.Locker = dialect.MySQL{}
+
+	assert.Equal(t, "select GET_LOCK(?, -1)", l.LockStatement())
+	assert.Equal(t, "select RELEASE_LOCK(?)", l.UnlockStatement())
+	assert.Equal(t, "my-lock", l.LockKey("my-lock"))
+}
+
+func TestSQLite3NotLocker(t *testing.T) {
+	_, ok := interface{}(dialect.SQLite3{}).(dialect.Locker)
+	require.False(t, ok)
+}
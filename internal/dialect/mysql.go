@@ -0,0 +1,46 @@
+package dialect
+
+// MySQL is the DialectQuery implementation for MySQL and MariaDB, using `?`
+// positional placeholders.
+type MySQL struct{}
+
+// CreateVersionTable returns the statement that creates the dbVersion table.
+func (MySQL) CreateVersionTable() string {
+	return `create table if not exists dbVersion (version int not null primary key, createdAt bigint not null)`
+}
+
+// InsertVersion returns the statement that records a migration version as applied.
+func (MySQL) InsertVersion() string {
+	return `insert into dbVersion (version, createdAt) values (?, ?)`
+}
+
+// DeleteVersion returns the statement that removes a migration version's row.
+func (MySQL) DeleteVersion() string {
+	return `delete from dbVersion where version = ?`
+}
+
+// GetLatestVersion returns the statement that selects the highest applied version.
+func (MySQL) GetLatestVersion() string {
+	return `select * from dbVersion order by version desc limit 1`
+}
+
+// ListAppliedVersions returns the statement that lists all applied versions.
+func (MySQL) ListAppliedVersions() string {
+	return `select version from dbVersion order by version asc`
+}
+
+// LockStatement returns the statement that acquires a MySQL named lock,
+// blocking indefinitely until it is available.
+func (MySQL) LockStatement() string {
+	return `select GET_LOCK(?, -1)`
+}
+
+// UnlockStatement returns the statement that releases a MySQL named lock.
+func (MySQL) UnlockStatement() string {
+	return `select RELEASE_LOCK(?)`
+}
+
+// LockKey returns the name of the MySQL named lock.
+func (MySQL) LockKey(name string) interface{} {
+	return name
+}
@@ -0,0 +1,46 @@
+package dialect
+
+// Postgres is the DialectQuery implementation for PostgreSQL, using $n
+// positional placeholders.
+type Postgres struct{}
+
+// CreateVersionTable returns the statement that creates the dbVersion table.
+func (Postgres) CreateVersionTable() string {
+	return `create table if not exists dbVersion (version int not null primary key, createdAt bigint not null)`
+}
+
+// InsertVersion returns the statement that records a migration version as applied.
+func (Postgres) InsertVersion() string {
+	return `insert into dbVersion (version, createdAt) values ($1, $2)`
+}
+
+// DeleteVersion returns the statement that removes a migration version's row.
+func (Postgres) DeleteVersion() string {
+	return `delete from dbVersion where version = $1`
+}
+
+// GetLatestVersion returns the statement that selects the highest applied version.
+func (Postgres) GetLatestVersion() string {
+	return `select * from dbVersion order by version desc limit 1`
+}
+
+// ListAppliedVersions returns the statement that lists all applied versions.
+func (Postgres) ListAppliedVersions() string {
+	return `select version from dbVersion order by version asc`
+}
+
+// LockStatement returns the statement that acquires a Postgres advisory lock.
+func (Postgres) LockStatement() string {
+	return `select pg_advisory_lock($1)`
+}
+
+// UnlockStatement returns the statement that releases a Postgres advisory lock.
+func (Postgres) UnlockStatement() string {
+	return `select pg_advisory_unlock($1)`
+}
+
+// LockKey returns the advisory lock's key, hashed down to the int64 that
+// pg_advisory_lock expects.
+func (Postgres) LockKey(name string) interface{} {
+	return HashLockName(name)
+}
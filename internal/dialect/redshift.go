@@ -0,0 +1,30 @@
+package dialect
+
+// Redshift is the DialectQuery implementation for Amazon Redshift, which
+// speaks PostgreSQL's wire protocol and $n placeholder syntax.
+type Redshift struct{}
+
+// CreateVersionTable returns the statement that creates the dbVersion table.
+func (Redshift) CreateVersionTable() string {
+	return `create table if not exists dbVersion (version int not null primary key, createdAt bigint not null)`
+}
+
+// InsertVersion returns the statement that records a migration version as applied.
+func (Redshift) InsertVersion() string {
+	return `insert into dbVersion (version, createdAt) values ($1, $2)`
+}
+
+// DeleteVersion returns the statement that removes a migration version's row.
+func (Redshift) DeleteVersion() string {
+	return `delete from dbVersion where version = $1`
+}
+
+// GetLatestVersion returns the statement that selects the highest applied version.
+func (Redshift) GetLatestVersion() string {
+	return `select * from dbVersion order by version desc limit 1`
+}
+
+// ListAppliedVersions returns the statement that lists all applied versions.
+func (Redshift) ListAppliedVersions() string {
+	return `select version from dbVersion order by version asc`
+}
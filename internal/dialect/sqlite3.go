@@ -0,0 +1,30 @@
+package dialect
+
+// SQLite3 is the DialectQuery implementation for SQLite, using `?` positional
+// placeholders.
+type SQLite3 struct{}
+
+// CreateVersionTable returns the statement that creates the dbVersion table.
+func (SQLite3) CreateVersionTable() string {
+	return `create table if not exists dbVersion (version int not null primary key, createdAt bigint not null)`
+}
+
+// InsertVersion returns the statement that records a migration version as applied.
+func (SQLite3) InsertVersion() string {
+	return `insert into dbVersion (version, createdAt) values (?, ?)`
+}
+
+// DeleteVersion returns the statement that removes a migration version's row.
+func (SQLite3) DeleteVersion() string {
+	return `delete from dbVersion where version = ?`
+}
+
+// GetLatestVersion returns the statement that selects the highest applied version.
+func (SQLite3) GetLatestVersion() string {
+	return `select * from dbVersion order by version desc limit 1`
+}
+
+// ListAppliedVersions returns the statement that lists all applied versions.
+func (SQLite3) ListAppliedVersions() string {
+	return `select version from dbVersion order by version asc`
+}
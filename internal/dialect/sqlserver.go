@@ -0,0 +1,30 @@
+package dialect
+
+// SQLServer is the DialectQuery implementation for Microsoft SQL Server,
+// using `@p1`-style named placeholders and `top` instead of `limit`.
+type SQLServer struct{}
+
+// CreateVersionTable returns the statement that creates the dbVersion table.
+func (SQLServer) CreateVersionTable() string {
+	return `if not exists (select * from sysobjects where name = 'dbVersion' and xtype = 'U') create table dbVersion (version int not null primary key, createdAt bigint not null)`
+}
+
+// InsertVersion returns the statement that records a migration version as applied.
+func (SQLServer) InsertVersion() string {
+	return `insert into dbVersion (version, createdAt) values (@p1, @p2)`
+}
+
+// DeleteVersion returns the statement that removes a migration version's row.
+func (SQLServer) DeleteVersion() string {
+	return `delete from dbVersion where version = @p1`
+}
+
+// GetLatestVersion returns the statement that selects the highest applied version.
+func (SQLServer) GetLatestVersion() string {
+	return `select top 1 * from dbVersion order by version desc`
+}
+
+// ListAppliedVersions returns the statement that lists all applied versions.
+func (SQLServer) ListAppliedVersions() string {
+	return `select version from dbVersion order by version asc`
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"os"
 	"path"
@@ -15,12 +16,7 @@ import (
 	"time"
 
 	"github.com/rickbassham/database"
-)
-
-const (
-	createDbVersionTable = `create table if not exists dbVersion (version int not null primary key, createdAt bigint not null)`
-	getDbVersion         = `select * from dbVersion order by version desc limit 1`
-	addVersion           = `insert into dbVersion (version, createdAt) values (?, ?)`
+	"github.com/rickbassham/database/internal/dialect"
 )
 
 var (
@@ -32,26 +28,145 @@ var (
 
 	// ErrInvalidPluginMigration is returned when an invalid .so file was loaded as a plugin migration.
 	ErrInvalidPluginMigration = errors.New("invalid plugin migration")
+
+	// ErrNotLocked is returned by Unlock, for dialects with native advisory
+	// locking, when called without a prior successful Lock. Dialects that use
+	// the sentinel row do not check for a prior Lock.
+	ErrNotLocked = errors.New("not locked")
+
+	// ErrUnknownMigrationVersion is returned by MigrateTo when targetVersion
+	// doesn't match any loaded migration's version (and isn't -1).
+	ErrUnknownMigrationVersion = errors.New("unknown migration version")
+)
+
+// lockSentinelVersion is a reserved dbVersion row used as a mutex by dialects
+// that have no native advisory locking. It is lower than any real migration
+// version, so it never affects getDbVersion.
+const lockSentinelVersion = -1
+
+// ErrVersionMismatch is returned by CheckVersion when the database is not at
+// the highest version known to the Service.
+type ErrVersionMismatch struct {
+	// Current is the version the database is currently at.
+	Current int
+	// Latest is the highest version known to the Service.
+	Latest int
+}
+
+func (e *ErrVersionMismatch) Error() string {
+	return fmt.Sprintf("database is at version %d, want %d", e.Current, e.Latest)
+}
+
+// Direction indicates which way a migration should be applied.
+type Direction int
+
+const (
+	// Up applies a migration forward.
+	Up Direction = iota
+	// Down rolls a migration back.
+	Down
 )
 
+// String returns "up" or "down".
+func (d Direction) String() string {
+	if d == Down {
+		return "down"
+	}
+
+	return "up"
+}
+
+// Logger is a minimal logging interface a Service can use to report progress
+// as migrations are applied.
+type Logger interface {
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Infof(format string, args ...interface{}) {}
+func (nopLogger) Warnf(format string, args ...interface{}) {}
+
+// defaultLockName is the advisory lock name used when WithLockName isn't
+// given. Services sharing a database with other callers of this package
+// should set their own name, or they'll contend for the same lock.
+const defaultLockName = "rickbassham/database/migrate"
+
 // Service is responsible for holding state to upgrade the database.
 type Service struct {
-	db *database.Database
+	db       *database.Database
+	dialect  dialect.DialectQuery
+	logger   Logger
+	timeout  time.Duration
+	lockName string
 
 	migrations []Migration
 	err        error
+
+	// lockTx holds the transaction opened by Lock for dialects with native
+	// advisory locking, so Unlock can release it on the same connection.
+	lockTx database.Tx
+}
+
+// Option configures optional behavior of a Service.
+type Option func(*Service)
+
+// WithDialect selects the DialectQuery used to build the SQL that tracks
+// applied migrations. The default is dialect.SQLite3{}.
+func WithDialect(d dialect.DialectQuery) Option {
+	return func(svc *Service) {
+		svc.dialect = d
+	}
+}
+
+// WithLogger sets the Logger used to report migration progress. The default
+// is a no-op logger.
+func WithLogger(l Logger) Option {
+	return func(svc *Service) {
+		svc.logger = l
+	}
 }
 
-// NewService creates a new Service.
-func NewService(db *database.Database) (*Service, error) {
-	err := db.RegisterStatement("CREATE_DB_VERSION_TABLE", createDbVersionTable)
+// WithTimeout bounds how long a single migration's Run is allowed to take. A
+// zero duration, the default, means no per-migration timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(svc *Service) {
+		svc.timeout = d
+	}
+}
+
+// WithLockName sets the name used to identify this Service's advisory lock
+// on dialects that support one (Postgres, MySQL). Services that share a
+// database with other callers of this package should each use a distinct
+// name, or they'll serialize (or, under MySQL's GET_LOCK, deadlock) against
+// each other's unrelated migrations.
+func WithLockName(name string) Option {
+	return func(svc *Service) {
+		svc.lockName = name
+	}
+}
+
+// NewService creates a new Service. By default it tracks migrations using
+// SQLite3 syntax; pass WithDialect to target a different database.
+func NewService(db *database.Database, opts ...Option) (*Service, error) {
+	svc := &Service{
+		db:       db,
+		dialect:  dialect.SQLite3{},
+		logger:   nopLogger{},
+		lockName: defaultLockName,
+	}
+
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	err := db.RegisterStatement("CREATE_DB_VERSION_TABLE", svc.dialect.CreateVersionTable())
 	if err != nil {
 		return nil, fmt.Errorf("register statement CREATE_DB_VERSION_TABLE: %w", err)
 	}
 
-	return &Service{
-		db: db,
-	}, nil
+	return svc, nil
 }
 
 // Init ensures all necessary tables exist to keep migration history.
@@ -61,21 +176,146 @@ func (svc *Service) Init() (err error) {
 		return fmt.Errorf("exec CREATE_DB_VERSION_TABLE: %w", err)
 	}
 
-	err = svc.db.RegisterStatement("GET_DB_VERSION", getDbVersion)
+	err = svc.db.RegisterStatement("GET_DB_VERSION", svc.dialect.GetLatestVersion())
 	if err != nil {
 		return fmt.Errorf("register statement GET_DB_VERSION: %w", err)
 	}
 
-	err = svc.db.RegisterStatement("ADD_VERSION", addVersion)
+	err = svc.db.RegisterStatement("ADD_VERSION", svc.dialect.InsertVersion())
 	if err != nil {
 		return fmt.Errorf("register statement ADD_VERSION: %w", err)
 	}
 
+	err = svc.db.RegisterStatement("DELETE_VERSION", svc.dialect.DeleteVersion())
+	if err != nil {
+		return fmt.Errorf("register statement DELETE_VERSION: %w", err)
+	}
+
+	err = svc.db.RegisterStatement("LIST_APPLIED_VERSIONS", svc.dialect.ListAppliedVersions())
+	if err != nil {
+		return fmt.Errorf("register statement LIST_APPLIED_VERSIONS: %w", err)
+	}
+
+	if locker, ok := svc.dialect.(dialect.Locker); ok {
+		err = svc.db.RegisterStatement("MIGRATE_LOCK", locker.LockStatement())
+		if err != nil {
+			return fmt.Errorf("register statement MIGRATE_LOCK: %w", err)
+		}
+
+		err = svc.db.RegisterStatement("MIGRATE_UNLOCK", locker.UnlockStatement())
+		if err != nil {
+			return fmt.Errorf("register statement MIGRATE_UNLOCK: %w", err)
+		}
+	}
+
 	return
 }
 
+// Lock acquires a cross-process lock so that only one instance at a time can
+// apply migrations. Dialects with native advisory locking (Postgres, MySQL)
+// hold it for a single pinned connection, via a transaction kept open until
+// Unlock, since the lock/unlock statements are scoped to the session that
+// took the lock; others fall back to a sentinel row in dbVersion. Callers
+// must pair a successful Lock with Unlock.
+func (svc *Service) Lock(ctx context.Context) error {
+	locker, ok := svc.dialect.(dialect.Locker)
+	if !ok {
+		_, err := svc.db.Insert(ctx, "ADD_VERSION", lockSentinelVersion, time.Now().Unix())
+		if err != nil {
+			return fmt.Errorf("lock: %w", err)
+		}
+
+		return nil
+	}
+
+	tx, err := svc.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("lock: begin tx: %w", err)
+	}
+
+	_, err = svc.db.ExecTx(ctx, tx, "MIGRATE_LOCK", locker.LockKey(svc.lockName))
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("lock: %w", err)
+	}
+
+	svc.lockTx = tx
+
+	return nil
+}
+
+// Unlock releases the lock acquired by Lock.
+func (svc *Service) Unlock(ctx context.Context) error {
+	locker, ok := svc.dialect.(dialect.Locker)
+	if !ok {
+		_, err := svc.db.Exec(ctx, "DELETE_VERSION", lockSentinelVersion)
+		if err != nil {
+			return fmt.Errorf("unlock: %w", err)
+		}
+
+		return nil
+	}
+
+	tx := svc.lockTx
+	if tx == nil {
+		return fmt.Errorf("unlock: %w", ErrNotLocked)
+	}
+
+	svc.lockTx = nil
+
+	_, err := svc.db.ExecTx(ctx, tx, "MIGRATE_UNLOCK", locker.LockKey(svc.lockName))
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("unlock: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// UpgradeAll repeatedly applies Upgrade until there are no more migrations to
+// run, holding the migration lock for the whole run so that only one
+// instance can migrate the database at a time.
+func (svc *Service) UpgradeAll(ctx context.Context) error {
+	if err := svc.Lock(ctx); err != nil {
+		return fmt.Errorf("lock: %w", err)
+	}
+
+	defer svc.Unlock(ctx)
+
+	for svc.Upgrade(ctx) {
+	}
+
+	return svc.err
+}
+
+// CheckVersion verifies the database is at exactly the highest version known
+// to this Service, returning *ErrVersionMismatch if it is not.
+func (svc *Service) CheckVersion(ctx context.Context) error {
+	current, err := svc.getDbVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("get db version: %w", err)
+	}
+
+	latest := -1
+	for _, m := range svc.migrations {
+		if m.Version() > latest {
+			latest = m.Version()
+		}
+	}
+
+	if current != latest {
+		return &ErrVersionMismatch{Current: current, Latest: latest}
+	}
+
+	return nil
+}
+
 // LoadMigrations will load all of the sql and so files in the given path.
-func (svc *Service) LoadMigrations(path string) error {
+func (svc *Service) LoadMigrations(ctx context.Context, path string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	_, err := svc.getMigrations(path)
 	if err != nil {
 		return fmt.Errorf("%w", err)
@@ -84,17 +324,74 @@ func (svc *Service) LoadMigrations(path string) error {
 	return nil
 }
 
-// Err returns any error that happened as part of Upgrade.
+// LoadMigrationsFS will load all of the sql files at root within fsys, which
+// may be an embed.FS, so migrations can be compiled into the binary with
+// `//go:embed`. Plugin (.so) migrations are not supported from an io/fs.FS,
+// since they must be opened from a real file on disk.
+func (svc *Service) LoadMigrationsFS(ctx context.Context, fsys fs.FS, root string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	entries, err := fs.ReadDir(fsys, root)
+	if err != nil {
+		return fmt.Errorf("readdir: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		p := path.Join(root, entry.Name())
+
+		extn := strings.ToLower(path.Ext(entry.Name()))
+
+		if extn != ".sql" {
+			return fmt.Errorf("extension %s: %w", extn, ErrUnknownMigrationType)
+		}
+
+		m, err := NewSQLMigrationFromFS(fsys, p)
+		if err != nil {
+			return err
+		}
+
+		svc.migrations = append(svc.migrations, m)
+	}
+
+	sort.SliceStable(svc.migrations, func(i, j int) bool {
+		return svc.migrations[i].Version() < svc.migrations[j].Version()
+	})
+
+	return nil
+}
+
+// Err returns any error that happened as part of Upgrade or Downgrade.
 func (svc *Service) Err() error {
 	return svc.err
 }
 
+// runMigration wraps a single migration.Run call in the Service's configured
+// per-migration timeout, if any.
+func (svc *Service) runMigration(ctx context.Context, migration Migration, tx database.Tx, direction Direction) error {
+	runCtx := ctx
+
+	if svc.timeout > 0 {
+		var cancel context.CancelFunc
+
+		runCtx, cancel = context.WithTimeout(ctx, svc.timeout)
+		defer cancel()
+	}
+
+	return migration.Run(runCtx, svc.db, tx, direction)
+}
+
 // Upgrade will upgrade the database by one version. Check Err() after running.
 // Returns true if a migration was run, false otherwise.
-func (svc *Service) Upgrade() bool {
+func (svc *Service) Upgrade(ctx context.Context) bool {
 	svc.err = nil
 
-	version, err := svc.getDbVersion()
+	version, err := svc.getDbVersion(ctx)
 	if err != nil {
 		svc.err = fmt.Errorf("get db version: %w", err)
 		return false
@@ -103,9 +400,10 @@ func (svc *Service) Upgrade() bool {
 	for _, migration := range svc.migrations {
 		if migration.Version() > version {
 			var tx database.Tx
-			t := time.Now().Unix()
+			start := time.Now()
+			t := start.Unix()
 
-			tx, err = svc.db.BeginTx(context.Background(), nil)
+			tx, err = svc.db.BeginTx(ctx, nil)
 			if err != nil {
 				svc.err = fmt.Errorf("begin tx: %w", err)
 				return false
@@ -115,18 +413,19 @@ func (svc *Service) Upgrade() bool {
 				if err != nil && tx != nil {
 					rollbackErr := tx.Rollback()
 					if rollbackErr != nil {
+						svc.logger.Warnf("rollback failed: %s", rollbackErr)
 						svc.err = fmt.Errorf("rollback: %w", err)
 					}
 				}
 			}()
 
-			err = migration.Run(svc.db, tx)
+			err = svc.runMigration(ctx, migration, tx, Up)
 			if err != nil {
 				svc.err = fmt.Errorf("exec: %w", err)
 				return false
 			}
 
-			_, err = svc.db.Insert(context.Background(), "ADD_VERSION", migration.Version(), t)
+			_, err = svc.db.Insert(ctx, "ADD_VERSION", migration.Version(), t)
 			if err != nil {
 				svc.err = fmt.Errorf("add version: %w", err)
 				return false
@@ -140,6 +439,77 @@ func (svc *Service) Upgrade() bool {
 
 			tx = nil
 
+			svc.logger.Infof("applied migration: version=%d name=%q direction=%s elapsed=%s", migration.Version(), migration.Name(), Up, time.Since(start))
+
+			return true
+		}
+	}
+
+	svc.err = nil
+	return false
+}
+
+// Downgrade will roll back the most recently applied migration by one version.
+// Check Err() after running. Returns true if a migration was rolled back, false
+// otherwise.
+func (svc *Service) Downgrade(ctx context.Context) bool {
+	svc.err = nil
+
+	version, err := svc.getDbVersion(ctx)
+	if err != nil {
+		svc.err = fmt.Errorf("get db version: %w", err)
+		return false
+	}
+
+	if version < 0 {
+		return false
+	}
+
+	for i := len(svc.migrations) - 1; i >= 0; i-- {
+		migration := svc.migrations[i]
+
+		if migration.Version() == version {
+			var tx database.Tx
+			start := time.Now()
+
+			tx, err = svc.db.BeginTx(ctx, nil)
+			if err != nil {
+				svc.err = fmt.Errorf("begin tx: %w", err)
+				return false
+			}
+
+			defer func() {
+				if err != nil && tx != nil {
+					rollbackErr := tx.Rollback()
+					if rollbackErr != nil {
+						svc.logger.Warnf("rollback failed: %s", rollbackErr)
+						svc.err = fmt.Errorf("rollback: %w", err)
+					}
+				}
+			}()
+
+			err = svc.runMigration(ctx, migration, tx, Down)
+			if err != nil {
+				svc.err = fmt.Errorf("exec: %w", err)
+				return false
+			}
+
+			_, err = svc.db.Exec(ctx, "DELETE_VERSION", migration.Version())
+			if err != nil {
+				svc.err = fmt.Errorf("delete version: %w", err)
+				return false
+			}
+
+			err = tx.Commit()
+			if err != nil {
+				svc.err = fmt.Errorf("commit: %w", err)
+				return false
+			}
+
+			tx = nil
+
+			svc.logger.Infof("rolled back migration: version=%d name=%q direction=%s elapsed=%s", migration.Version(), migration.Name(), Down, time.Since(start))
+
 			return true
 		}
 	}
@@ -148,12 +518,62 @@ func (svc *Service) Upgrade() bool {
 	return false
 }
 
+// MigrateTo upgrades or downgrades the database, one version at a time, until
+// it reaches targetVersion. targetVersion must be -1 (no migrations applied)
+// or the version of a loaded migration; Upgrade and Downgrade only ever stop
+// on a loaded migration's version, so any other target would never be
+// reached and MigrateTo returns ErrUnknownMigrationVersion instead of
+// looping forever.
+func (svc *Service) MigrateTo(ctx context.Context, targetVersion int) error {
+	if targetVersion != -1 {
+		known := false
+
+		for _, m := range svc.migrations {
+			if m.Version() == targetVersion {
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			return fmt.Errorf("version %d: %w", targetVersion, ErrUnknownMigrationVersion)
+		}
+	}
+
+	for {
+		version, err := svc.getDbVersion(ctx)
+		if err != nil {
+			return fmt.Errorf("get db version: %w", err)
+		}
+
+		if version == targetVersion {
+			return nil
+		}
+
+		var ok bool
+
+		if version < targetVersion {
+			ok = svc.Upgrade(ctx)
+		} else {
+			ok = svc.Downgrade(ctx)
+		}
+
+		if svc.err != nil {
+			return svc.err
+		}
+
+		if !ok {
+			return fmt.Errorf("no migration found to reach version %d", targetVersion)
+		}
+	}
+}
+
 // CurrentVersion returns the current version of our database.
-func (svc *Service) CurrentVersion() (int, error) {
-	return svc.getDbVersion()
+func (svc *Service) CurrentVersion(ctx context.Context) (int, error) {
+	return svc.getDbVersion(ctx)
 }
 
-func (svc *Service) getDbVersion() (version int, err error) {
+func (svc *Service) getDbVersion(ctx context.Context) (version int, err error) {
 	type dbVersion struct {
 		Version   int   `db:"version"`
 		CreatedAt int64 `db:"createdAt"`
@@ -161,7 +581,7 @@ func (svc *Service) getDbVersion() (version int, err error) {
 
 	var result []dbVersion
 
-	err = svc.db.Select(context.Background(), &result, "GET_DB_VERSION")
+	err = svc.db.Select(ctx, &result, "GET_DB_VERSION")
 	if err != nil {
 		err = fmt.Errorf("select: %w", err)
 		return
@@ -182,6 +602,18 @@ func (svc *Service) AddMigration(m Migration) {
 	svc.migrations = append(svc.migrations, m)
 }
 
+// LoadRegistered adds every migration registered with r.Register to this
+// Service.
+func (svc *Service) LoadRegistered(r *Registry) {
+	for _, m := range r.migrations {
+		svc.migrations = append(svc.migrations, m)
+	}
+
+	sort.SliceStable(svc.migrations, func(i, j int) bool {
+		return svc.migrations[i].Version() < svc.migrations[j].Version()
+	})
+}
+
 func (svc *Service) getMigrations(folder string) ([]Migration, error) {
 	var files []os.FileInfo
 	var err error
@@ -228,42 +660,99 @@ func (svc *Service) getMigrations(folder string) ([]Migration, error) {
 type Migration interface {
 	Runner
 	Version() int
+	// Name is a human-readable name for the migration, used for logging.
+	Name() string
 }
 
-// Runner defines the method used to actually run a migration.
+// Runner defines the method used to actually run a migration in the given direction.
 type Runner interface {
-	Run(*database.Database, database.Tx) error
+	Run(ctx context.Context, db *database.Database, tx database.Tx, direction Direction) error
 }
 
 // SQLMigration is a simple SQL statement based migration.
 type SQLMigration struct {
-	path      string
-	statement string
-	version   int
+	path    string
+	name    string
+	up      string
+	down    string
+	version int
+}
+
+// StepRunner is implemented by a single direction (up or down) of a plugin migration.
+type StepRunner interface {
+	Run(ctx context.Context, db *database.Database, tx database.Tx) error
 }
 
 // PluginMigration is a migration loaded from a golang .so plugin.
 type PluginMigration struct {
 	path    string
+	name    string
 	version int
-	runner  Runner
+	up      StepRunner
+	down    StepRunner
 }
 
 var (
-	fileNameRegex = regexp.MustCompile(`(\d+)_(.*?)\.(sql|so)`)
+	fileNameRegex     = regexp.MustCompile(`(\d+)_(.*?)\.(sql|so)`)
+	migrateUpMarker   = regexp.MustCompile(`(?m)^--\s*\+migrate Up\s*$`)
+	migrateDownMarker = regexp.MustCompile(`(?m)^--\s*\+migrate Down\s*$`)
 )
 
-// NewSQLMigration creates a new SQL migration.
-func NewSQLMigration(version int, statement string) SQLMigration {
+// NewSQLMigration creates a new SQL migration with no down statement.
+func NewSQLMigration(version int, up string) SQLMigration {
 	return SQLMigration{
-		version:   version,
-		statement: statement,
+		version: version,
+		up:      up,
 	}
 }
 
-// NewSQLMigrationFile creates a new SQL migration from the given file. The file name
-// must match the regex `(\d+)_(.*?)\.(sql|so)`.
-func NewSQLMigrationFile(path string) (m SQLMigration, err error) {
+// NewSQLMigrationWithDown creates a new SQL migration with explicit up and down statements.
+func NewSQLMigrationWithDown(version int, up, down string) SQLMigration {
+	return SQLMigration{
+		version: version,
+		up:      up,
+		down:    down,
+	}
+}
+
+// splitUpDown splits a migration file's contents into its up and down sections,
+// delimited by `-- +migrate Up` and `-- +migrate Down` marker comments. A file
+// with no markers is treated as up-only, for backwards compatibility. It
+// returns ErrInvalidMigrationFileName if a `-- +migrate Down` marker appears
+// before the `-- +migrate Up` marker.
+func splitUpDown(body string) (up, down string, err error) {
+	up = body
+
+	upLoc := migrateUpMarker.FindStringIndex(body)
+	downLoc := migrateDownMarker.FindStringIndex(body)
+
+	if downLoc == nil {
+		if upLoc != nil {
+			up = body[upLoc[1]:]
+		}
+
+		return
+	}
+
+	if upLoc != nil && upLoc[0] > downLoc[0] {
+		err = ErrInvalidMigrationFileName
+		return
+	}
+
+	down = body[downLoc[1]:]
+
+	if upLoc != nil {
+		up = body[upLoc[1]:downLoc[0]]
+	} else {
+		up = body[:downLoc[0]]
+	}
+
+	return
+}
+
+// newSQLMigrationFromBytes builds a SQLMigration from a migration file's raw
+// contents. It is shared by the os.Open and io/fs loading paths.
+func newSQLMigrationFromBytes(path string, b []byte) (m SQLMigration, err error) {
 	matches := fileNameRegex.FindStringSubmatch(path)
 	if len(matches) == 0 {
 		err = ErrInvalidMigrationFileName
@@ -272,6 +761,26 @@ func NewSQLMigrationFile(path string) (m SQLMigration, err error) {
 
 	version, _ := strconv.Atoi(matches[1])
 
+	up, down, err := splitUpDown(string(b))
+	if err != nil {
+		return
+	}
+
+	m.path = path
+	m.name = matches[2]
+	m.up = up
+	m.down = down
+	m.version = version
+	return
+}
+
+// NewSQLMigrationFile creates a new SQL migration from the given file. The file name
+// must match the regex `(\d+)_(.*?)\.(sql|so)`.
+//
+// Deprecated: use NewSQLMigrationFromFS with os.DirFS, or LoadMigrationsFS
+// directly, which share the same parser without requiring migrations to
+// live on disk.
+func NewSQLMigrationFile(path string) (m SQLMigration, err error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return
@@ -282,21 +791,40 @@ func NewSQLMigrationFile(path string) (m SQLMigration, err error) {
 		return
 	}
 
-	m.path = path
-	m.statement = string(b)
-	m.version = version
-	return
+	return newSQLMigrationFromBytes(path, b)
 }
 
-// Run will run the sql statement against the database.
-func (m SQLMigration) Run(db *database.Database, tx database.Tx) error {
-	key := fmt.Sprintf("DB_MIGRATION_%d", m.Version())
-	err := db.RegisterStatement(key, m.statement)
+// NewSQLMigrationFromFS creates a new SQL migration from the file at path
+// within fsys, which may be an embed.FS. The file name must match the regex
+// `(\d+)_(.*?)\.(sql|so)`.
+func NewSQLMigrationFromFS(fsys fs.FS, path string) (m SQLMigration, err error) {
+	b, err := fs.ReadFile(fsys, path)
 	if err != nil {
+		return
+	}
+
+	return newSQLMigrationFromBytes(path, b)
+}
+
+// Run will run the sql statement against the database for the given direction.
+// If no down statement was given, downgrading is a no-op.
+func (m SQLMigration) Run(ctx context.Context, db *database.Database, tx database.Tx, direction Direction) error {
+	if direction == Down && m.down == "" {
 		return nil
 	}
 
-	_, err = db.ExecTx(context.Background(), tx, key)
+	statement := m.up
+	if direction == Down {
+		statement = m.down
+	}
+
+	key := fmt.Sprintf("DB_MIGRATION_%d_%s", m.Version(), direction)
+	err := db.RegisterStatement(key, statement)
+	if err != nil {
+		return nil
+	}
+
+	_, err = db.ExecTx(ctx, tx, key)
 
 	return err
 }
@@ -306,7 +834,14 @@ func (m SQLMigration) Version() int {
 	return m.version
 }
 
-// NewPluginMigration creates a Migration from a golang .so plugin file.
+// Name is a human-readable name for the migration, used for logging.
+func (m SQLMigration) Name() string {
+	return m.name
+}
+
+// NewPluginMigration creates a Migration from a golang .so plugin file. The plugin
+// must export an `Up` symbol, and may optionally export a `Down` symbol, each
+// implementing StepRunner.
 func NewPluginMigration(path string) (m PluginMigration, err error) {
 	matches := fileNameRegex.FindStringSubmatch(path)
 	if len(matches) == 0 {
@@ -321,30 +856,118 @@ func NewPluginMigration(path string) (m PluginMigration, err error) {
 		return
 	}
 
-	symRunner, err := plug.Lookup("Runner")
+	symUp, err := plug.Lookup("Up")
 	if err != nil {
 		return
 	}
 
-	r, ok := symRunner.(Runner)
+	up, ok := symUp.(StepRunner)
 	if !ok {
 		err = ErrInvalidPluginMigration
 		return
 	}
 
+	var down StepRunner
+
+	if symDown, lookupErr := plug.Lookup("Down"); lookupErr == nil {
+		down, ok = symDown.(StepRunner)
+		if !ok {
+			err = ErrInvalidPluginMigration
+			return
+		}
+	}
+
 	m.version = version
+	m.name = matches[2]
 	m.path = path
-	m.runner = r
+	m.up = up
+	m.down = down
 
 	return
 }
 
-// Run will run the sql statement against the database.
-func (m PluginMigration) Run(db *database.Database, tx database.Tx) error {
-	return m.runner.Run(db, tx)
+// Run will run the plugin's Up or Down symbol, depending on direction. If no
+// Down symbol was found, downgrading is a no-op.
+func (m PluginMigration) Run(ctx context.Context, db *database.Database, tx database.Tx, direction Direction) error {
+	if direction == Down {
+		if m.down == nil {
+			return nil
+		}
+
+		return m.down.Run(ctx, db, tx)
+	}
+
+	return m.up.Run(ctx, db, tx)
 }
 
 // Version is the version number of the migration.
 func (m PluginMigration) Version() int {
 	return m.version
 }
+
+// Name is a human-readable name for the migration, used for logging.
+func (m PluginMigration) Name() string {
+	return m.name
+}
+
+// GoMigration is a migration implemented directly in Go and registered with
+// Register, rather than loaded from a .so plugin. This lets migrations
+// (data backfills, calls into other packages) compile straight into the main
+// binary, which also means they work on platforms where the plugin package
+// isn't available.
+type GoMigration struct {
+	version int
+	name    string
+	up      func(ctx context.Context, tx database.Tx) error
+	down    func(ctx context.Context, tx database.Tx) error
+}
+
+// Registry collects Go-function migrations registered with Register, so that
+// each Service only loads the migrations meant for it via LoadRegistered.
+// Processes that manage more than one database should use a separate
+// Registry per database, rather than sharing one.
+type Registry struct {
+	migrations []GoMigration
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds a Go-function migration to this Registry, to be pulled into
+// a Service by LoadRegistered. up is required; down may be nil if the
+// migration cannot be rolled back.
+func (r *Registry) Register(version int, name string, up, down func(ctx context.Context, tx database.Tx) error) {
+	r.migrations = append(r.migrations, GoMigration{
+		version: version,
+		name:    name,
+		up:      up,
+		down:    down,
+	})
+}
+
+// Run will run the registered Up or Down function, depending on direction. If
+// no Down function was registered, downgrading is a no-op.
+func (m GoMigration) Run(ctx context.Context, db *database.Database, tx database.Tx, direction Direction) error {
+	fn := m.up
+	if direction == Down {
+		fn = m.down
+	}
+
+	if fn == nil {
+		return nil
+	}
+
+	return fn(ctx, tx)
+}
+
+// Version is the version number of the migration.
+func (m GoMigration) Version() int {
+	return m.version
+}
+
+// Name is a human-readable name for the migration, used for logging.
+func (m GoMigration) Name() string {
+	return m.name
+}
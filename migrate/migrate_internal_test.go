@@ -0,0 +1,140 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rickbassham/database"
+)
+
+func TestDirectionString(t *testing.T) {
+	assert.Equal(t, "up", Up.String())
+	assert.Equal(t, "down", Down.String())
+}
+
+func TestSplitUpDown(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantUp   string
+		wantDown string
+		wantErr  error
+	}{
+		{
+			name:   "no markers",
+			body:   "create table foo (id int);",
+			wantUp: "create table foo (id int);",
+		},
+		{
+			name:     "up and down markers",
+			body:     "-- +migrate Up\ncreate table foo (id int);\n-- +migrate Down\ndrop table foo;\n",
+			wantUp:   "\ncreate table foo (id int);\n",
+			wantDown: "\ndrop table foo;\n",
+		},
+		{
+			name:   "up marker only",
+			body:   "-- +migrate Up\ncreate table foo (id int);\n",
+			wantUp: "\ncreate table foo (id int);\n",
+		},
+		{
+			name:    "down before up",
+			body:    "-- +migrate Down\ndrop table foo;\n-- +migrate Up\ncreate table foo (id int);\n",
+			wantErr: ErrInvalidMigrationFileName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up, down, err := splitUpDown(tt.body)
+
+			if tt.wantErr != nil {
+				require.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUp, up)
+			assert.Equal(t, tt.wantDown, down)
+		})
+	}
+}
+
+func TestNewSQLMigrationFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"1_create_foo.sql": {Data: []byte("-- +migrate Up\ncreate table foo (id int);\n-- +migrate Down\ndrop table foo;\n")},
+	}
+
+	m, err := NewSQLMigrationFromFS(fsys, "1_create_foo.sql")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, m.Version())
+	assert.Equal(t, "create_foo", m.Name())
+	assert.Equal(t, "\ncreate table foo (id int);\n", m.up)
+	assert.Equal(t, "\ndrop table foo;\n", m.down)
+}
+
+func TestNewSQLMigrationFromFSInvalidName(t *testing.T) {
+	fsys := fstest.MapFS{
+		"not_a_migration.sql": {Data: []byte("create table foo (id int);")},
+	}
+
+	_, err := NewSQLMigrationFromFS(fsys, "not_a_migration.sql")
+	require.True(t, errors.Is(err, ErrInvalidMigrationFileName))
+}
+
+func TestLoadMigrationsFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/2_second.sql": {Data: []byte("create table bar (id int);")},
+		"migrations/1_first.sql":  {Data: []byte("create table foo (id int);")},
+	}
+
+	svc := &Service{}
+
+	err := svc.LoadMigrationsFS(context.Background(), fsys, "migrations")
+	require.NoError(t, err)
+
+	require.Len(t, svc.migrations, 2)
+	assert.Equal(t, 1, svc.migrations[0].Version())
+	assert.Equal(t, 2, svc.migrations[1].Version())
+}
+
+func TestLoadMigrationsFSUnknownType(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/1_first.txt": {Data: []byte("not sql")},
+	}
+
+	svc := &Service{}
+
+	err := svc.LoadMigrationsFS(context.Background(), fsys, "migrations")
+	require.True(t, errors.Is(err, ErrUnknownMigrationType))
+}
+
+func TestRegistryLoadRegistered(t *testing.T) {
+	r := NewRegistry()
+	r.Register(2, "second", func(ctx context.Context, tx database.Tx) error { return nil }, nil)
+	r.Register(1, "first", func(ctx context.Context, tx database.Tx) error { return nil }, nil)
+
+	svc := &Service{}
+	svc.LoadRegistered(r)
+
+	require.Len(t, svc.migrations, 2)
+	assert.Equal(t, 1, svc.migrations[0].Version())
+	assert.Equal(t, 2, svc.migrations[1].Version())
+}
+
+func TestMigrateToUnknownVersion(t *testing.T) {
+	svc := &Service{
+		migrations: []Migration{
+			NewSQLMigration(1, "create table foo (id int);"),
+			NewSQLMigration(2, "create table bar (id int);"),
+		},
+	}
+
+	err := svc.MigrateTo(context.Background(), 3)
+	require.True(t, errors.Is(err, ErrUnknownMigrationVersion))
+}
@@ -1,6 +1,7 @@
 package migrate_test
 
 import (
+	"context"
 	"log"
 
 	"github.com/rickbassham/database"
@@ -8,12 +9,14 @@ import (
 )
 
 func Example() {
+	ctx := context.Background()
+
 	db, err := database.New(nil)
 	if err != nil {
 		panic(err.Error())
 	}
 
-	migration, err := migrate.NewMigrateDB(db)
+	migration, err := migrate.NewService(db)
 	if err != nil {
 		panic(err.Error())
 	}
@@ -25,15 +28,15 @@ func Example() {
 
 	migration.AddMigration(migrate.NewSQLMigration(1, "CREATE TABLE test (id integer, name varchar(50));"))
 
-	version, err := migration.CurrentVersion()
+	version, err := migration.CurrentVersion(ctx)
 	if err != nil {
 		panic(err.Error())
 	}
 
 	log.Printf("starting version: %d\n", version)
 
-	for migration.Upgrade() {
-		version, err := migration.CurrentVersion()
+	for migration.Upgrade(ctx) {
+		version, err := migration.CurrentVersion(ctx)
 		if err != nil {
 			panic(err.Error())
 		}